@@ -0,0 +1,70 @@
+package mongosoftdelete
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// BulkWrite runs a bulk write, rewriting each model's filter through
+// addSoftDeleteFilter and converting DeleteOneModel/DeleteManyModel into the
+// equivalent UpdateOneModel/UpdateManyModel that performs a soft delete
+// instead, so existing bulk pipelines keep soft-delete semantics without
+// having to migrate model-by-model. deletedBy is stamped on every converted
+// delete model, the same as SoftDeleteOne/SoftDeleteMany; pass
+// primitive.NilObjectID if the caller has no actor to record. A single bulk
+// call can only record one actor for all of its deletes — split deletes by
+// actor across separate BulkWrite calls if that's not acceptable.
+func (m *SoftDeleteMiddleware) BulkWrite(ctx context.Context, models []mongo.WriteModel, deletedBy primitive.ObjectID, opts ...*options.BulkWriteOptions) (*mongo.BulkWriteResult, error) {
+	rewritten := make([]mongo.WriteModel, 0, len(models))
+	for _, writeModel := range models {
+		rewritten = append(rewritten, m.rewriteBulkWriteModel(writeModel, deletedBy))
+	}
+
+	return m.Collection.BulkWrite(ctx, rewritten, opts...)
+}
+
+// rewriteBulkWriteModel applies BulkWrite's per-model rewrite to a single
+// write model, factored out so the rewrite (which model types gain a
+// soft-delete filter, which are converted to an update, and which pass
+// through untouched) can be asserted on directly without a live mongo
+// connection.
+func (m *SoftDeleteMiddleware) rewriteBulkWriteModel(writeModel mongo.WriteModel, deletedBy primitive.ObjectID) mongo.WriteModel {
+	switch model := writeModel.(type) {
+	case *mongo.UpdateOneModel:
+		model.Filter = m.addSoftDeleteFilter(model.Filter)
+		return model
+	case *mongo.UpdateManyModel:
+		model.Filter = m.addSoftDeleteFilter(model.Filter)
+		return model
+	case *mongo.ReplaceOneModel:
+		model.Filter = m.addSoftDeleteFilter(model.Filter)
+		return model
+	case *mongo.DeleteOneModel:
+		update := mongo.NewUpdateOneModel().
+			SetFilter(m.addSoftDeleteFilter(model.Filter)).
+			SetUpdate(m.createSoftDeleteUpdate(deletedBy))
+		if model.Collation != nil {
+			update.SetCollation(model.Collation)
+		}
+		if model.Hint != nil {
+			update.SetHint(model.Hint)
+		}
+		return update
+	case *mongo.DeleteManyModel:
+		update := mongo.NewUpdateManyModel().
+			SetFilter(m.addSoftDeleteFilter(model.Filter)).
+			SetUpdate(m.createSoftDeleteUpdate(deletedBy))
+		if model.Collation != nil {
+			update.SetCollation(model.Collation)
+		}
+		if model.Hint != nil {
+			update.SetHint(model.Hint)
+		}
+		return update
+	default:
+		return writeModel
+	}
+}
@@ -0,0 +1,114 @@
+package mongosoftdelete
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestRewriteBulkWriteModelUpdateOneAddsSoftDeleteFilter(t *testing.T) {
+	m := &SoftDeleteMiddleware{scope: scopeActive}
+	model := mongo.NewUpdateOneModel().SetFilter(bson.M{"name": "a"}).SetUpdate(bson.M{"$set": bson.M{"name": "b"}})
+
+	got, ok := m.rewriteBulkWriteModel(model, primitive.NilObjectID).(*mongo.UpdateOneModel)
+	if !ok {
+		t.Fatalf("rewriteBulkWriteModel() returned %T, want *mongo.UpdateOneModel", got)
+	}
+	want := m.addSoftDeleteFilter(bson.M{"name": "a"})
+	if !reflect.DeepEqual(got.Filter, want) {
+		t.Errorf("Filter = %v, want %v", got.Filter, want)
+	}
+}
+
+func TestRewriteBulkWriteModelReplaceOneAddsSoftDeleteFilter(t *testing.T) {
+	m := &SoftDeleteMiddleware{scope: scopeActive}
+	model := mongo.NewReplaceOneModel().SetFilter(bson.M{"name": "a"}).SetReplacement(bson.M{"name": "b"})
+
+	got, ok := m.rewriteBulkWriteModel(model, primitive.NilObjectID).(*mongo.ReplaceOneModel)
+	if !ok {
+		t.Fatalf("rewriteBulkWriteModel() returned %T, want *mongo.ReplaceOneModel", got)
+	}
+	want := m.addSoftDeleteFilter(bson.M{"name": "a"})
+	if !reflect.DeepEqual(got.Filter, want) {
+		t.Errorf("Filter = %v, want %v", got.Filter, want)
+	}
+}
+
+func TestRewriteBulkWriteModelDeleteOneConvertsToUpdate(t *testing.T) {
+	m := &SoftDeleteMiddleware{scope: scopeActive}
+	deletedBy := primitive.NewObjectID()
+	collation := &options.Collation{Locale: "en"}
+	model := mongo.NewDeleteOneModel().SetFilter(bson.M{"name": "a"}).SetCollation(collation).SetHint("name_1")
+
+	got, ok := m.rewriteBulkWriteModel(model, deletedBy).(*mongo.UpdateOneModel)
+	if !ok {
+		t.Fatalf("rewriteBulkWriteModel() returned %T, want *mongo.UpdateOneModel", got)
+	}
+
+	wantFilter := m.addSoftDeleteFilter(bson.M{"name": "a"})
+	if !reflect.DeepEqual(got.Filter, wantFilter) {
+		t.Errorf("Filter = %v, want %v", got.Filter, wantFilter)
+	}
+
+	wantUpdate := m.createSoftDeleteUpdate(deletedBy)
+	gotUpdate, ok := got.Update.(bson.M)
+	if !ok {
+		t.Fatalf("Update is %T, want bson.M", got.Update)
+	}
+	if gotUpdate["$set"].(bson.M)["deletedBy"] != wantUpdate["$set"].(bson.M)["deletedBy"] {
+		t.Errorf("Update $set.deletedBy = %v, want %v", gotUpdate["$set"].(bson.M)["deletedBy"], wantUpdate["$set"].(bson.M)["deletedBy"])
+	}
+
+	if got.Collation != collation {
+		t.Errorf("Collation = %v, want %v (DeleteOneModel's Collation must carry over to the converted UpdateOneModel)", got.Collation, collation)
+	}
+	if got.Hint != "name_1" {
+		t.Errorf("Hint = %v, want %v", got.Hint, "name_1")
+	}
+}
+
+func TestRewriteBulkWriteModelDeleteManyConvertsToUpdate(t *testing.T) {
+	m := &SoftDeleteMiddleware{scope: scopeActive}
+	deletedBy := primitive.NewObjectID()
+	model := mongo.NewDeleteManyModel().SetFilter(bson.M{"status": "stale"})
+
+	got, ok := m.rewriteBulkWriteModel(model, deletedBy).(*mongo.UpdateManyModel)
+	if !ok {
+		t.Fatalf("rewriteBulkWriteModel() returned %T, want *mongo.UpdateManyModel", got)
+	}
+
+	wantFilter := m.addSoftDeleteFilter(bson.M{"status": "stale"})
+	if !reflect.DeepEqual(got.Filter, wantFilter) {
+		t.Errorf("Filter = %v, want %v", got.Filter, wantFilter)
+	}
+}
+
+func TestRewriteBulkWriteModelDeleteWithoutCollationOrHint(t *testing.T) {
+	m := &SoftDeleteMiddleware{scope: scopeActive}
+	model := mongo.NewDeleteOneModel().SetFilter(bson.M{"name": "a"})
+
+	got, ok := m.rewriteBulkWriteModel(model, primitive.NilObjectID).(*mongo.UpdateOneModel)
+	if !ok {
+		t.Fatalf("rewriteBulkWriteModel() returned %T, want *mongo.UpdateOneModel", got)
+	}
+	if got.Collation != nil {
+		t.Errorf("Collation = %v, want nil", got.Collation)
+	}
+	if got.Hint != nil {
+		t.Errorf("Hint = %v, want nil", got.Hint)
+	}
+}
+
+func TestRewriteBulkWriteModelUnknownPassesThrough(t *testing.T) {
+	m := &SoftDeleteMiddleware{scope: scopeActive}
+	model := mongo.NewInsertOneModel().SetDocument(bson.M{"name": "a"})
+
+	got := m.rewriteBulkWriteModel(model, primitive.NilObjectID)
+	if got != mongo.WriteModel(model) {
+		t.Errorf("rewriteBulkWriteModel() = %v, want the original model passed through unchanged", got)
+	}
+}
@@ -0,0 +1,92 @@
+package mongosoftdelete
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CountDocuments adds a soft delete filter to the query before counting, so
+// soft-deleted documents are excluded (or, under a trashed scope, included
+// or exclusively targeted) like every other query method.
+func (m *SoftDeleteMiddleware) CountDocuments(ctx context.Context, filter interface{}, opts ...*options.CountOptions) (int64, error) {
+	filter = m.addSoftDeleteFilter(filter)
+	return m.Collection.CountDocuments(ctx, filter, opts...)
+}
+
+// EstimatedDocumentCount returns the collection's estimated document count
+// when the scope is WithTrashed, since no filtering is needed in that case.
+// For the default (active-only) and OnlyTrashed scopes, where a filter is
+// required to get an accurate count, it falls back to CountDocuments.
+func (m *SoftDeleteMiddleware) EstimatedDocumentCount(ctx context.Context, opts ...*options.EstimatedDocumentCountOptions) (int64, error) {
+	if m.scope == scopeWithTrashed {
+		return m.Collection.EstimatedDocumentCount(ctx, opts...)
+	}
+	return m.CountDocuments(ctx, bson.M{})
+}
+
+// Distinct adds a soft delete filter to the query before finding distinct values.
+func (m *SoftDeleteMiddleware) Distinct(ctx context.Context, fieldName string, filter interface{}, opts ...*options.DistinctOptions) ([]interface{}, error) {
+	filter = m.addSoftDeleteFilter(filter)
+	return m.Collection.Distinct(ctx, fieldName, filter, opts...)
+}
+
+// Watch opens a change stream filtered by the current scope, the same way
+// Find/Aggregate are: the default (active) scope excludes an update that
+// sets deleted:true, scopeOnlyTrashed keeps only updates that set
+// deleted:true, and scopeWithTrashed (m.WithTrashed()) skips the prepended
+// $match entirely so every event passes through. The filter matches on
+// updateDescription.updatedFields, which every update event carries by
+// default, rather than on fullDocument, which is only populated when the
+// caller requests the UpdateLookup full-document option. Use
+// SoftDeleteChangeStream.SoftDeleteEventType to tell a real soft delete apart
+// from a plain update.
+func (m *SoftDeleteMiddleware) Watch(ctx context.Context, pipeline interface{}, opts ...*options.ChangeStreamOptions) (*SoftDeleteChangeStream, error) {
+	pipelineArray, err := toPipelineArray(pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	newPipeline := pipelineArray
+	if scopeMatch := m.scope.watchMatchFilter(); scopeMatch != nil {
+		softDeleteMatch := bson.D{{Key: "$match", Value: scopeMatch}}
+		newPipeline = append([]interface{}{softDeleteMatch}, pipelineArray...)
+	}
+
+	stream, err := m.Collection.Watch(ctx, newPipeline, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &SoftDeleteChangeStream{ChangeStream: stream}, nil
+}
+
+// SoftDeleteChangeStream wraps *mongo.ChangeStream to additionally recognize
+// updates that set deleted:true as a synthetic "softDelete" event type.
+type SoftDeleteChangeStream struct {
+	*mongo.ChangeStream
+}
+
+// SoftDeleteEventType returns the change event's operationType, except for an
+// update whose updateDescription sets deleted:true, which is reported as the
+// synthetic type "softDelete" instead of "update".
+func (s *SoftDeleteChangeStream) SoftDeleteEventType() string {
+	var event struct {
+		OperationType     string `bson:"operationType"`
+		UpdateDescription struct {
+			UpdatedFields bson.M `bson:"updatedFields"`
+		} `bson:"updateDescription"`
+	}
+
+	if err := s.ChangeStream.Decode(&event); err != nil {
+		return ""
+	}
+
+	if event.OperationType == "update" {
+		if deleted, ok := event.UpdateDescription.UpdatedFields["deleted"].(bool); ok && deleted {
+			return "softDelete"
+		}
+	}
+	return event.OperationType
+}
@@ -0,0 +1,92 @@
+package mongosoftdelete
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestTrashScopeWatchMatchFilter(t *testing.T) {
+	tests := []struct {
+		name  string
+		scope trashScope
+		want  bson.M
+	}{
+		{
+			name:  "active",
+			scope: scopeActive,
+			want: bson.M{
+				"$or": bson.A{
+					bson.M{"operationType": bson.M{"$ne": "update"}},
+					bson.M{"updateDescription.updatedFields.deleted": bson.M{"$ne": true}},
+				},
+			},
+		},
+		{"withTrashed", scopeWithTrashed, nil},
+		{
+			name:  "onlyTrashed",
+			scope: scopeOnlyTrashed,
+			want: bson.M{
+				"operationType": "update",
+				"updateDescription.updatedFields.deleted": true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.scope.watchMatchFilter(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("watchMatchFilter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToPipelineArray(t *testing.T) {
+	tests := []struct {
+		name     string
+		pipeline interface{}
+		want     []interface{}
+		wantErr  bool
+	}{
+		{
+			name:     "[]interface{} passes through",
+			pipeline: []interface{}{bson.D{{Key: "$match", Value: bson.M{}}}},
+			want:     []interface{}{bson.D{{Key: "$match", Value: bson.M{}}}},
+		},
+		{
+			name:     "bson.D wraps into a single-stage slice",
+			pipeline: bson.D{{Key: "$match", Value: bson.M{}}},
+			want:     []interface{}{bson.D{{Key: "$match", Value: bson.M{}}}},
+		},
+		{
+			name:     "bson.A is used as-is",
+			pipeline: bson.A{bson.D{{Key: "$match", Value: bson.M{}}}},
+			want:     []interface{}{bson.D{{Key: "$match", Value: bson.M{}}}},
+		},
+		{
+			name:     "unsupported type errors",
+			pipeline: "not a pipeline",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := toPipelineArray(tt.pipeline)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("toPipelineArray() error = nil, want non-nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("toPipelineArray() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toPipelineArray() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
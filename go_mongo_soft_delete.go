@@ -13,14 +13,21 @@ import (
 
 // SoftDeleteModel contains common fields for soft deletion
 type SoftDeleteModel struct {
-	Deleted   bool               `bson:"deleted,omitempty"`
-	DeletedAt primitive.DateTime `bson:"deletedAt,omitempty"`
-	DeletedBy primitive.ObjectID `bson:"deletedBy,omitempty"`
+	Deleted         bool                   `bson:"deleted,omitempty"`
+	DeletedAt       primitive.DateTime     `bson:"deletedAt,omitempty"`
+	DeletedBy       primitive.ObjectID     `bson:"deletedBy,omitempty"`
+	RestoredAt      primitive.DateTime     `bson:"restoredAt,omitempty"`
+	RestoredBy      primitive.ObjectID     `bson:"restoredBy,omitempty"`
+	DeletionHistory []DeletionHistoryEntry `bson:"deletionHistory,omitempty"`
 }
 
 // SoftDeleteMiddleware adds soft delete filter to all queries
 type SoftDeleteMiddleware struct {
 	*mongo.Collection
+	scope       trashScope
+	hooks       *hookRegistry
+	cascades    *cascadeRegistry
+	indexConfig *indexConfig
 }
 
 // ISoftDeleteMiddleware defines the interface for soft deletion operations
@@ -30,7 +37,33 @@ type ISoftDeleteMiddleware interface {
 	SoftDeleteOne(ctx context.Context, filter interface{}, deletedBy primitive.ObjectID) (*mongo.UpdateResult, error)
 	SoftDeleteMany(ctx context.Context, filter interface{}, deletedBy primitive.ObjectID) (*mongo.UpdateResult, error)
 	SoftDeleteByID(ctx context.Context, id primitive.ObjectID, deletedBy primitive.ObjectID) (*mongo.UpdateResult, error)
+	RestoreOne(ctx context.Context, filter interface{}, restoredBy primitive.ObjectID) (*mongo.UpdateResult, error)
+	RestoreMany(ctx context.Context, filter interface{}, restoredBy primitive.ObjectID) (*mongo.UpdateResult, error)
+	RestoreByID(ctx context.Context, id primitive.ObjectID, restoredBy primitive.ObjectID) (*mongo.UpdateResult, error)
+	WithTrashed() *SoftDeleteMiddleware
+	OnlyTrashed() *SoftDeleteMiddleware
 	Aggregate(ctx context.Context, pipeline interface{}, opts ...*options.AggregateOptions) (*mongo.Cursor, error)
+	BeforeInsert(hook BeforeInsertHook)
+	AfterInsert(hook AfterInsertHook)
+	BeforeUpdate(hook BeforeUpdateHook)
+	AfterUpdate(hook AfterUpdateHook)
+	BeforeSoftDelete(hook BeforeSoftDeleteHook)
+	AfterSoftDelete(hook AfterSoftDeleteHook)
+	BeforeRestore(hook BeforeRestoreHook)
+	AfterRestore(hook AfterRestoreHook)
+	BeforeFind(hook BeforeFindHook)
+	AfterFind(hook AfterFindHook)
+	WithSession(ctx context.Context, fn func(sessCtx mongo.SessionContext) error) error
+	Transaction(ctx context.Context, fn func(sessCtx mongo.SessionContext) error) error
+	Register(childColl *mongo.Collection, fkField string)
+	CascadeSoftDeleteByID(ctx context.Context, id primitive.ObjectID, deletedBy primitive.ObjectID) error
+	EnsureIndexes(ctx context.Context, compoundIndexes ...[]string) error
+	SetHardDeleteAfter(after time.Duration)
+	BulkWrite(ctx context.Context, models []mongo.WriteModel, deletedBy primitive.ObjectID, opts ...*options.BulkWriteOptions) (*mongo.BulkWriteResult, error)
+	CountDocuments(ctx context.Context, filter interface{}, opts ...*options.CountOptions) (int64, error)
+	EstimatedDocumentCount(ctx context.Context, opts ...*options.EstimatedDocumentCountOptions) (int64, error)
+	Distinct(ctx context.Context, fieldName string, filter interface{}, opts ...*options.DistinctOptions) ([]interface{}, error)
+	Watch(ctx context.Context, pipeline interface{}, opts ...*options.ChangeStreamOptions) (*SoftDeleteChangeStream, error)
 	UpdateByID(ctx context.Context, id primitive.ObjectID, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error)
 	UpdateOne(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error)
 	UpdateMany(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error)
@@ -42,128 +75,234 @@ type ISoftDeleteMiddleware interface {
 }
 
 func New(coll *mongo.Collection) *SoftDeleteMiddleware {
-	return &SoftDeleteMiddleware{Collection: coll}
+	return &SoftDeleteMiddleware{Collection: coll, hooks: &hookRegistry{}, cascades: &cascadeRegistry{}, indexConfig: &indexConfig{}}
 }
 
 // Find adds a soft delete filter to the query. It ensures that only documents with deleted=false are returned.
 func (m *SoftDeleteMiddleware) Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (*mongo.Cursor, error) {
+	if err := m.hooks.runBeforeFind(ctx, filter); err != nil {
+		return nil, err
+	}
 	filter = m.addSoftDeleteFilter(filter)
-	return m.Collection.Find(ctx, filter, opts...)
+	cursor, err := m.Collection.Find(ctx, filter, opts...)
+	if err != nil {
+		return cursor, err
+	}
+	return cursor, m.hooks.runAfterFind(ctx, filter)
 }
 
 // FindOne adds a soft delete filter to the query. It ensures that only documents with deleted=false are returned.
 func (m *SoftDeleteMiddleware) FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) *mongo.SingleResult {
+	if err := m.hooks.runBeforeFind(ctx, filter); err != nil {
+		return mongo.NewSingleResultFromDocument(bson.D{}, err, nil)
+	}
 	filter = m.addSoftDeleteFilter(filter)
-	return m.Collection.FindOne(ctx, filter, opts...)
+	result := m.Collection.FindOne(ctx, filter, opts...)
+	if err := m.hooks.runAfterFind(ctx, filter); err != nil {
+		return mongo.NewSingleResultFromDocument(bson.D{}, err, nil)
+	}
+	return result
 }
 
-// SoftDeleteOne performs a soft delete operation
+// SoftDeleteOne performs a soft delete operation. filter is an arbitrary
+// query document, not a document instance, so BeforeSoftDelete/AfterSoftDelete
+// hookable methods only fire when filter itself happens to implement them;
+// use Repository[T]'s by-ID methods when you need the hook to see the actual
+// document.
 func (m *SoftDeleteMiddleware) SoftDeleteOne(ctx context.Context, filter interface{}, deletedBy primitive.ObjectID) (*mongo.UpdateResult, error) {
+	if err := m.hooks.runBeforeSoftDelete(ctx, filter, nil); err != nil {
+		return nil, err
+	}
 	update := m.createSoftDeleteUpdate(deletedBy)
-	return m.Collection.UpdateOne(ctx, filter, update)
+	result, err := m.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return result, err
+	}
+	return result, m.hooks.runAfterSoftDelete(ctx, filter, nil)
 }
 
-// SoftDeleteMany performs a soft delete operation on multiple documents
+// SoftDeleteMany performs a soft delete operation on multiple documents. As
+// with SoftDeleteOne, hookable methods only fire if filter implements them.
 func (m *SoftDeleteMiddleware) SoftDeleteMany(ctx context.Context, filter interface{}, deletedBy primitive.ObjectID) (*mongo.UpdateResult, error) {
+	if err := m.hooks.runBeforeSoftDelete(ctx, filter, nil); err != nil {
+		return nil, err
+	}
 	update := m.createSoftDeleteUpdate(deletedBy)
-	return m.Collection.UpdateMany(ctx, filter, update)
+	result, err := m.Collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return result, err
+	}
+	return result, m.hooks.runAfterSoftDelete(ctx, filter, nil)
 }
 
-// SoftDeleteByID performs a soft delete operation on a document by its ID
+// SoftDeleteByID performs a soft delete operation on a document by its ID.
+// filter is always the {"_id": id} query document built here, never the
+// document itself, so BeforeSoftDelete/AfterSoftDelete hookable methods never
+// fire through this entry point; use Repository[T].SoftDeleteByID, which
+// fetches the typed document and offers it to the hook, when that matters.
 func (m *SoftDeleteMiddleware) SoftDeleteByID(ctx context.Context, id primitive.ObjectID, deletedBy primitive.ObjectID) (*mongo.UpdateResult, error) {
 	filter := bson.M{"_id": id}
+	if err := m.hooks.runBeforeSoftDelete(ctx, filter, nil); err != nil {
+		return nil, err
+	}
 	update := m.createSoftDeleteUpdate(deletedBy)
-	return m.Collection.UpdateOne(ctx, filter, update)
+	result, err := m.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return result, err
+	}
+	return result, m.hooks.runAfterSoftDelete(ctx, filter, nil)
 }
 
 // Add the new methods to SoftDeleteMiddleware
 // Aggregate adds a soft delete filter to the aggregation pipeline
 func (m *SoftDeleteMiddleware) Aggregate(ctx context.Context, pipeline interface{}, opts ...*options.AggregateOptions) (*mongo.Cursor, error) {
-	// Convert pipeline to array if it's not already
-	pipelineArray, ok := pipeline.([]interface{})
-	if !ok {
-		switch p := pipeline.(type) {
-		case bson.D:
-			pipelineArray = []interface{}{p}
-		case bson.A:
-			pipelineArray = p
-		default:
-			return nil, fmt.Errorf("pipeline must be []interface{}, bson.D or bson.A, got %T", pipeline)
-		}
+	pipelineArray, err := toPipelineArray(pipeline)
+	if err != nil {
+		return nil, err
 	}
 
-	// Add $match stage with soft delete filter at the beginning of pipeline
-	softDeleteMatch := bson.D{{
-		Key: "$match",
-		Value: bson.M{
-			"deleted": bson.M{"$ne": true},
-		},
-	}}
-
-	newPipeline := append([]interface{}{softDeleteMatch}, pipelineArray...)
+	// Add $match stage with soft delete filter (honoring the current scope) at the beginning of
+	// pipeline. scopeWithTrashed has no filter to apply, so skip the stage rather than prepend a
+	// $match against a nil value.
+	scopeFilter := m.scope.matchFilter()
+	newPipeline := pipelineArray
+	if len(scopeFilter) > 0 {
+		softDeleteMatch := bson.D{{Key: "$match", Value: scopeFilter}}
+		newPipeline = append([]interface{}{softDeleteMatch}, pipelineArray...)
+	}
 
 	return m.Collection.Aggregate(ctx, newPipeline, opts...)
 }
 
+// toPipelineArray normalizes an aggregation/change-stream pipeline argument
+// ([]interface{}, bson.D, or bson.A) into a []interface{} so a stage can be
+// prepended to it.
+func toPipelineArray(pipeline interface{}) ([]interface{}, error) {
+	if pipelineArray, ok := pipeline.([]interface{}); ok {
+		return pipelineArray, nil
+	}
+
+	switch p := pipeline.(type) {
+	case bson.D:
+		return []interface{}{p}, nil
+	case bson.A:
+		return p, nil
+	default:
+		return nil, fmt.Errorf("pipeline must be []interface{}, bson.D or bson.A, got %T", pipeline)
+	}
+}
+
 // UpdateByID updates a single document by ID
 func (m *SoftDeleteMiddleware) UpdateByID(ctx context.Context, id primitive.ObjectID, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
-	filter := bson.M{
-		"_id":     id,
-		"deleted": bson.M{"$ne": true},
-	}
+	filter := m.addSoftDeleteFilter(bson.M{"_id": id})
 
-	return m.Collection.UpdateOne(ctx, filter, update, opts...)
+	if err := m.hooks.runBeforeUpdate(ctx, filter, update); err != nil {
+		return nil, err
+	}
+	result, err := m.Collection.UpdateOne(ctx, filter, update, opts...)
+	if err != nil {
+		return result, err
+	}
+	return result, m.hooks.runAfterUpdate(ctx, filter, update)
 }
 
 // You might also want to add a convenience method for updating non-deleted documents
 func (m *SoftDeleteMiddleware) UpdateOne(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
 	filter = m.addSoftDeleteFilter(filter)
-	return m.Collection.UpdateOne(ctx, filter, update, opts...)
+	if err := m.hooks.runBeforeUpdate(ctx, filter, update); err != nil {
+		return nil, err
+	}
+	result, err := m.Collection.UpdateOne(ctx, filter, update, opts...)
+	if err != nil {
+		return result, err
+	}
+	return result, m.hooks.runAfterUpdate(ctx, filter, update)
 }
 
 // And a method for updating many documents
 func (m *SoftDeleteMiddleware) UpdateMany(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
 	filter = m.addSoftDeleteFilter(filter)
-	return m.Collection.UpdateMany(ctx, filter, update, opts...)
+	if err := m.hooks.runBeforeUpdate(ctx, filter, update); err != nil {
+		return nil, err
+	}
+	result, err := m.Collection.UpdateMany(ctx, filter, update, opts...)
+	if err != nil {
+		return result, err
+	}
+	return result, m.hooks.runAfterUpdate(ctx, filter, update)
 }
 
 // FindOneAndUpdate adds a soft delete filter to the query and performs a find-and-update operation
 func (m *SoftDeleteMiddleware) FindOneAndUpdate(ctx context.Context, filter interface{}, update interface{}, opts ...*options.FindOneAndUpdateOptions) *mongo.SingleResult {
 	filter = m.addSoftDeleteFilter(filter)
-	return m.Collection.FindOneAndUpdate(ctx, filter, update, opts...)
+	if err := m.hooks.runBeforeUpdate(ctx, filter, update); err != nil {
+		return mongo.NewSingleResultFromDocument(bson.D{}, err, nil)
+	}
+	result := m.Collection.FindOneAndUpdate(ctx, filter, update, opts...)
+	if err := m.hooks.runAfterUpdate(ctx, filter, update); err != nil {
+		return mongo.NewSingleResultFromDocument(bson.D{}, err, nil)
+	}
+	return result
 }
 
 // InsertOne inserts a single document into the collection
 func (m *SoftDeleteMiddleware) InsertOne(ctx context.Context, document interface{}, opts ...*options.InsertOneOptions) (*mongo.InsertOneResult, error) {
-	return m.Collection.InsertOne(ctx, document, opts...)
+	if err := m.hooks.runBeforeInsert(ctx, document); err != nil {
+		return nil, err
+	}
+	result, err := m.Collection.InsertOne(ctx, document, opts...)
+	if err != nil {
+		return result, err
+	}
+	return result, m.hooks.runAfterInsert(ctx, document)
 }
 
 // InsertMany inserts multiple documents into the collection
 func (m *SoftDeleteMiddleware) InsertMany(ctx context.Context, documents []interface{}, opts ...*options.InsertManyOptions) (*mongo.InsertManyResult, error) {
-	return m.Collection.InsertMany(ctx, documents, opts...)
+	for _, document := range documents {
+		if err := m.hooks.runBeforeInsert(ctx, document); err != nil {
+			return nil, err
+		}
+	}
+	result, err := m.Collection.InsertMany(ctx, documents, opts...)
+	if err != nil {
+		return result, err
+	}
+	for _, document := range documents {
+		if err := m.hooks.runAfterInsert(ctx, document); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
 }
 
-// addSoftDeleteFilter adds a soft delete filter to the query. It ensures that only documents with deleted=false are returned.
+// addSoftDeleteFilter adds a soft delete filter to the query, honoring the
+// middleware's current scope (see WithTrashed and OnlyTrashed).
 func (m *SoftDeleteMiddleware) addSoftDeleteFilter(filter interface{}) interface{} {
+	scopeFilter := m.scope.matchFilter()
+	if len(scopeFilter) == 0 {
+		return filter
+	}
+
 	if filter == nil {
-		return bson.M{"deleted": bson.M{"$ne": true}}
+		return scopeFilter
 	}
 
 	return bson.M{
 		"$and": []interface{}{
 			filter,
-
-			// Handling when the field does not exist or is false.
-			bson.M{"deleted": bson.M{"$ne": true}},
+			scopeFilter,
 		},
 	}
 }
 
 // createSoftDeleteUpdate creates the update payload for a soft delete operation. It sets the deleted field to true and adds the deletedAt timestamp. If deletedBy is provided, it also sets the deletedBy field.
 func (m *SoftDeleteMiddleware) createSoftDeleteUpdate(deletedBy primitive.ObjectID) bson.M {
+	now := time.Now()
+
 	softDeletionPayload := bson.M{
 		"deleted":   true,
-		"deletedAt": primitive.NewDateTimeFromTime(time.Now()),
+		"deletedAt": primitive.NewDateTimeFromTime(now),
 	}
 
 	// handling when deletedBy is provided
@@ -171,7 +310,16 @@ func (m *SoftDeleteMiddleware) createSoftDeleteUpdate(deletedBy primitive.Object
 		softDeletionPayload["deletedBy"] = deletedBy
 	}
 
+	historyEntry := DeletionHistoryEntry{
+		Action: "deleted",
+		At:     primitive.NewDateTimeFromTime(now),
+		By:     deletedBy,
+	}
+
 	return bson.M{
 		"$set": softDeletionPayload,
+		"$push": bson.M{
+			"deletionHistory": historyEntry,
+		},
 	}
 }
@@ -0,0 +1,339 @@
+package mongosoftdelete
+
+import "context"
+
+// Hook function types registrable on a SoftDeleteMiddleware. A before-hook
+// returning an error aborts the operation before it reaches the database;
+// after-hooks that return an error are surfaced to the caller but the
+// operation itself has already completed.
+type (
+	BeforeInsertHook     func(ctx context.Context, document interface{}) error
+	AfterInsertHook      func(ctx context.Context, document interface{}) error
+	BeforeUpdateHook     func(ctx context.Context, filter interface{}, update interface{}) error
+	AfterUpdateHook      func(ctx context.Context, filter interface{}, update interface{}) error
+	BeforeSoftDeleteHook func(ctx context.Context, filter interface{}) error
+	AfterSoftDeleteHook  func(ctx context.Context, filter interface{}) error
+	BeforeRestoreHook    func(ctx context.Context, filter interface{}) error
+	AfterRestoreHook     func(ctx context.Context, filter interface{}) error
+	BeforeFindHook       func(ctx context.Context, filter interface{}) error
+	AfterFindHook        func(ctx context.Context, filter interface{}) error
+)
+
+// Hookable interfaces let a document type opt into the lifecycle by
+// implementing the relevant method; SoftDeleteMiddleware checks for these via
+// type assertion, so a document only implements the phases it cares about.
+type (
+	BeforeInsertHookable interface {
+		BeforeInsert(ctx context.Context) error
+	}
+	AfterInsertHookable interface {
+		AfterInsert(ctx context.Context) error
+	}
+	BeforeUpdateHookable interface {
+		BeforeUpdate(ctx context.Context) error
+	}
+	AfterUpdateHookable interface {
+		AfterUpdate(ctx context.Context) error
+	}
+	BeforeSoftDeleteHookable interface {
+		BeforeSoftDelete(ctx context.Context) error
+	}
+	AfterSoftDeleteHookable interface {
+		AfterSoftDelete(ctx context.Context) error
+	}
+	BeforeRestoreHookable interface {
+		BeforeRestore(ctx context.Context) error
+	}
+	AfterRestoreHookable interface {
+		AfterRestore(ctx context.Context) error
+	}
+)
+
+// hookRegistry holds the hooks registered on a SoftDeleteMiddleware. It lives
+// behind a pointer so that scoped copies (see WithTrashed and OnlyTrashed)
+// keep sharing the same registered hooks.
+type hookRegistry struct {
+	beforeInsert     []BeforeInsertHook
+	afterInsert      []AfterInsertHook
+	beforeUpdate     []BeforeUpdateHook
+	afterUpdate      []AfterUpdateHook
+	beforeSoftDelete []BeforeSoftDeleteHook
+	afterSoftDelete  []AfterSoftDeleteHook
+	beforeRestore    []BeforeRestoreHook
+	afterRestore     []AfterRestoreHook
+	beforeFind       []BeforeFindHook
+	afterFind        []AfterFindHook
+}
+
+// hookRegistryOf returns m's hook registry, lazily creating one if m was
+// constructed without New() (e.g. &SoftDeleteMiddleware{Collection: coll}),
+// so registering a hook never panics on a zero-value middleware.
+func (m *SoftDeleteMiddleware) hookRegistryOf() *hookRegistry {
+	if m.hooks == nil {
+		m.hooks = &hookRegistry{}
+	}
+	return m.hooks
+}
+
+// BeforeInsert registers a hook run before InsertOne/InsertMany. Hooks run in
+// registration order; the first error aborts the insert.
+func (m *SoftDeleteMiddleware) BeforeInsert(hook BeforeInsertHook) {
+	r := m.hookRegistryOf()
+	r.beforeInsert = append(r.beforeInsert, hook)
+}
+
+// AfterInsert registers a hook run after a successful InsertOne/InsertMany.
+func (m *SoftDeleteMiddleware) AfterInsert(hook AfterInsertHook) {
+	r := m.hookRegistryOf()
+	r.afterInsert = append(r.afterInsert, hook)
+}
+
+// BeforeUpdate registers a hook run before UpdateOne/UpdateMany/UpdateByID/FindOneAndUpdate.
+func (m *SoftDeleteMiddleware) BeforeUpdate(hook BeforeUpdateHook) {
+	r := m.hookRegistryOf()
+	r.beforeUpdate = append(r.beforeUpdate, hook)
+}
+
+// AfterUpdate registers a hook run after a successful update.
+func (m *SoftDeleteMiddleware) AfterUpdate(hook AfterUpdateHook) {
+	r := m.hookRegistryOf()
+	r.afterUpdate = append(r.afterUpdate, hook)
+}
+
+// BeforeSoftDelete registers a hook run before SoftDeleteOne/SoftDeleteMany/SoftDeleteByID.
+func (m *SoftDeleteMiddleware) BeforeSoftDelete(hook BeforeSoftDeleteHook) {
+	r := m.hookRegistryOf()
+	r.beforeSoftDelete = append(r.beforeSoftDelete, hook)
+}
+
+// AfterSoftDelete registers a hook run after a successful soft delete.
+func (m *SoftDeleteMiddleware) AfterSoftDelete(hook AfterSoftDeleteHook) {
+	r := m.hookRegistryOf()
+	r.afterSoftDelete = append(r.afterSoftDelete, hook)
+}
+
+// BeforeRestore registers a hook run before RestoreOne/RestoreMany/RestoreByID.
+func (m *SoftDeleteMiddleware) BeforeRestore(hook BeforeRestoreHook) {
+	r := m.hookRegistryOf()
+	r.beforeRestore = append(r.beforeRestore, hook)
+}
+
+// AfterRestore registers a hook run after a successful restore.
+func (m *SoftDeleteMiddleware) AfterRestore(hook AfterRestoreHook) {
+	r := m.hookRegistryOf()
+	r.afterRestore = append(r.afterRestore, hook)
+}
+
+// BeforeFind registers a hook run before Find/FindOne.
+func (m *SoftDeleteMiddleware) BeforeFind(hook BeforeFindHook) {
+	r := m.hookRegistryOf()
+	r.beforeFind = append(r.beforeFind, hook)
+}
+
+// AfterFind registers a hook run after Find/FindOne.
+func (m *SoftDeleteMiddleware) AfterFind(hook AfterFindHook) {
+	r := m.hookRegistryOf()
+	r.afterFind = append(r.afterFind, hook)
+}
+
+// hookableTarget picks which of an update call's two arguments to check for
+// a Hookable implementation: the update payload when it is itself a document
+// (e.g. a typed replacement passed to FindOneAndUpdate/ReplaceOne-style
+// calls), falling back to the filter.
+func hookableTarget(filter, update interface{}) interface{} {
+	switch update.(type) {
+	case BeforeUpdateHookable, AfterUpdateHookable:
+		return update
+	default:
+		return filter
+	}
+}
+
+// softDeleteHookTarget picks which of a soft-delete/restore call's two
+// arguments to check for a Hookable implementation: the target document when
+// one was fetched/decoded by the caller (e.g. Repository[T]'s by-ID methods,
+// which know the concrete document type), falling back to the filter. A
+// bson.M filter such as {"_id": id} can never implement BeforeSoftDeleteHookable
+// et al., so callers that only ever build a filter (SoftDeleteOne,
+// SoftDeleteMany, SoftDeleteByID on the untyped SoftDeleteMiddleware) have no
+// document to offer and pass nil; at those call sites a document-level hook
+// fires only when the filter itself implements it, which SoftDeleteByID's
+// bson.M filter never does.
+func softDeleteHookTarget(filter, document interface{}) interface{} {
+	switch document.(type) {
+	case BeforeSoftDeleteHookable, AfterSoftDeleteHookable, BeforeRestoreHookable, AfterRestoreHookable:
+		return document
+	default:
+		return filter
+	}
+}
+
+// Each insert/update/soft-delete/restore run* method below checks the
+// relevant Hookable interface first (which needs no registry at all, so it
+// fires even on a zero-value middleware), then walks the registered func
+// hooks, if any; the find runners have no Hookable interface and only walk
+// the registered hooks. r may be nil when the middleware was constructed
+// without New().
+
+func (r *hookRegistry) runBeforeInsert(ctx context.Context, document interface{}) error {
+	if h, ok := document.(BeforeInsertHookable); ok {
+		if err := h.BeforeInsert(ctx); err != nil {
+			return err
+		}
+	}
+	if r == nil {
+		return nil
+	}
+	for _, hook := range r.beforeInsert {
+		if err := hook(ctx, document); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *hookRegistry) runAfterInsert(ctx context.Context, document interface{}) error {
+	if h, ok := document.(AfterInsertHookable); ok {
+		if err := h.AfterInsert(ctx); err != nil {
+			return err
+		}
+	}
+	if r == nil {
+		return nil
+	}
+	for _, hook := range r.afterInsert {
+		if err := hook(ctx, document); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *hookRegistry) runBeforeUpdate(ctx context.Context, filter, update interface{}) error {
+	if h, ok := hookableTarget(filter, update).(BeforeUpdateHookable); ok {
+		if err := h.BeforeUpdate(ctx); err != nil {
+			return err
+		}
+	}
+	if r == nil {
+		return nil
+	}
+	for _, hook := range r.beforeUpdate {
+		if err := hook(ctx, filter, update); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *hookRegistry) runAfterUpdate(ctx context.Context, filter, update interface{}) error {
+	if h, ok := hookableTarget(filter, update).(AfterUpdateHookable); ok {
+		if err := h.AfterUpdate(ctx); err != nil {
+			return err
+		}
+	}
+	if r == nil {
+		return nil
+	}
+	for _, hook := range r.afterUpdate {
+		if err := hook(ctx, filter, update); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// document is the fetched/target document for this operation, or nil when
+// the caller only has a filter to offer (see softDeleteHookTarget).
+
+func (r *hookRegistry) runBeforeSoftDelete(ctx context.Context, filter, document interface{}) error {
+	if h, ok := softDeleteHookTarget(filter, document).(BeforeSoftDeleteHookable); ok {
+		if err := h.BeforeSoftDelete(ctx); err != nil {
+			return err
+		}
+	}
+	if r == nil {
+		return nil
+	}
+	for _, hook := range r.beforeSoftDelete {
+		if err := hook(ctx, filter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *hookRegistry) runAfterSoftDelete(ctx context.Context, filter, document interface{}) error {
+	if h, ok := softDeleteHookTarget(filter, document).(AfterSoftDeleteHookable); ok {
+		if err := h.AfterSoftDelete(ctx); err != nil {
+			return err
+		}
+	}
+	if r == nil {
+		return nil
+	}
+	for _, hook := range r.afterSoftDelete {
+		if err := hook(ctx, filter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *hookRegistry) runBeforeRestore(ctx context.Context, filter, document interface{}) error {
+	if h, ok := softDeleteHookTarget(filter, document).(BeforeRestoreHookable); ok {
+		if err := h.BeforeRestore(ctx); err != nil {
+			return err
+		}
+	}
+	if r == nil {
+		return nil
+	}
+	for _, hook := range r.beforeRestore {
+		if err := hook(ctx, filter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *hookRegistry) runAfterRestore(ctx context.Context, filter, document interface{}) error {
+	if h, ok := softDeleteHookTarget(filter, document).(AfterRestoreHookable); ok {
+		if err := h.AfterRestore(ctx); err != nil {
+			return err
+		}
+	}
+	if r == nil {
+		return nil
+	}
+	for _, hook := range r.afterRestore {
+		if err := hook(ctx, filter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *hookRegistry) runBeforeFind(ctx context.Context, filter interface{}) error {
+	if r == nil {
+		return nil
+	}
+	for _, hook := range r.beforeFind {
+		if err := hook(ctx, filter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *hookRegistry) runAfterFind(ctx context.Context, filter interface{}) error {
+	if r == nil {
+		return nil
+	}
+	for _, hook := range r.afterFind {
+		if err := hook(ctx, filter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package mongosoftdelete
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type fakeUpdateHookable struct {
+	beforeErr error
+	afterErr  error
+	before    bool
+	after     bool
+}
+
+func (f *fakeUpdateHookable) BeforeUpdate(ctx context.Context) error {
+	f.before = true
+	return f.beforeErr
+}
+
+func (f *fakeUpdateHookable) AfterUpdate(ctx context.Context) error {
+	f.after = true
+	return f.afterErr
+}
+
+type fakeSoftDeleteHookable struct {
+	before bool
+	after  bool
+}
+
+func (f *fakeSoftDeleteHookable) BeforeSoftDelete(ctx context.Context) error {
+	f.before = true
+	return nil
+}
+
+func (f *fakeSoftDeleteHookable) AfterSoftDelete(ctx context.Context) error {
+	f.after = true
+	return nil
+}
+
+type fakeRestoreHookable struct {
+	before bool
+	after  bool
+}
+
+func (f *fakeRestoreHookable) BeforeRestore(ctx context.Context) error {
+	f.before = true
+	return nil
+}
+
+func (f *fakeRestoreHookable) AfterRestore(ctx context.Context) error {
+	f.after = true
+	return nil
+}
+
+func TestHookableTarget(t *testing.T) {
+	filter := "filter"
+	hookableUpdate := &fakeUpdateHookable{}
+
+	tests := []struct {
+		name   string
+		filter interface{}
+		update interface{}
+		want   interface{}
+	}{
+		{"update implements Hookable, returned over filter", filter, hookableUpdate, hookableUpdate},
+		{"update does not implement Hookable, falls back to filter", filter, "plain update", filter},
+		{"update is nil, falls back to filter", filter, nil, filter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hookableTarget(tt.filter, tt.update); got != tt.want {
+				t.Errorf("hookableTarget(%v, %v) = %v, want %v", tt.filter, tt.update, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSoftDeleteHookTarget(t *testing.T) {
+	filter := "filter"
+	softDeleteDoc := &fakeSoftDeleteHookable{}
+	restoreDoc := &fakeRestoreHookable{}
+
+	tests := []struct {
+		name     string
+		filter   interface{}
+		document interface{}
+		want     interface{}
+	}{
+		{"document implements BeforeSoftDeleteHookable, returned over filter", filter, softDeleteDoc, softDeleteDoc},
+		{"document implements BeforeRestoreHookable, returned over filter", filter, restoreDoc, restoreDoc},
+		{"document is nil, falls back to filter", filter, nil, filter},
+		{"document does not implement any Hookable, falls back to filter", filter, "plain document", filter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := softDeleteHookTarget(tt.filter, tt.document); got != tt.want {
+				t.Errorf("softDeleteHookTarget(%v, %v) = %v, want %v", tt.filter, tt.document, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunBeforeUpdatePrefersHookableUpdateOverFilter(t *testing.T) {
+	r := &hookRegistry{}
+	hookable := &fakeUpdateHookable{}
+
+	if err := r.runBeforeUpdate(context.Background(), "filter", hookable); err != nil {
+		t.Fatalf("runBeforeUpdate() error = %v", err)
+	}
+	if !hookable.before {
+		t.Error("BeforeUpdate was not called on the update payload")
+	}
+}
+
+func TestRunBeforeUpdateAbortsOnHookableError(t *testing.T) {
+	r := &hookRegistry{}
+	wantErr := errors.New("veto")
+	hookable := &fakeUpdateHookable{beforeErr: wantErr}
+
+	funcHookCalled := false
+	r.beforeUpdate = append(r.beforeUpdate, func(ctx context.Context, filter, update interface{}) error {
+		funcHookCalled = true
+		return nil
+	})
+
+	if err := r.runBeforeUpdate(context.Background(), "filter", hookable); !errors.Is(err, wantErr) {
+		t.Fatalf("runBeforeUpdate() error = %v, want %v", err, wantErr)
+	}
+	if funcHookCalled {
+		t.Error("registered func hook ran after the Hookable method returned an error; it should have aborted first")
+	}
+}
+
+func TestRunBeforeSoftDeleteChecksDocumentOverFilter(t *testing.T) {
+	r := &hookRegistry{}
+	doc := &fakeSoftDeleteHookable{}
+
+	if err := r.runBeforeSoftDelete(context.Background(), "filter", doc); err != nil {
+		t.Fatalf("runBeforeSoftDelete() error = %v", err)
+	}
+	if !doc.before {
+		t.Error("BeforeSoftDelete was not called on the document")
+	}
+}
+
+func TestRunBeforeSoftDeleteWithNilDocumentChecksFilter(t *testing.T) {
+	r := &hookRegistry{}
+	filterDoc := &fakeSoftDeleteHookable{}
+
+	if err := r.runBeforeSoftDelete(context.Background(), filterDoc, nil); err != nil {
+		t.Fatalf("runBeforeSoftDelete() error = %v", err)
+	}
+	if !filterDoc.before {
+		t.Error("BeforeSoftDelete was not called on the filter when document is nil")
+	}
+}
+
+func TestRunAfterRestoreChecksDocumentOverFilter(t *testing.T) {
+	r := &hookRegistry{}
+	doc := &fakeRestoreHookable{}
+
+	if err := r.runAfterRestore(context.Background(), "filter", doc); err != nil {
+		t.Fatalf("runAfterRestore() error = %v", err)
+	}
+	if !doc.after {
+		t.Error("AfterRestore was not called on the document")
+	}
+}
+
+func TestHookRunnersNilRegistrySafe(t *testing.T) {
+	var r *hookRegistry
+	ctx := context.Background()
+
+	if err := r.runBeforeInsert(ctx, "doc"); err != nil {
+		t.Errorf("runBeforeInsert() on nil registry error = %v", err)
+	}
+	if err := r.runBeforeSoftDelete(ctx, "filter", nil); err != nil {
+		t.Errorf("runBeforeSoftDelete() on nil registry error = %v", err)
+	}
+	if err := r.runBeforeRestore(ctx, "filter", nil); err != nil {
+		t.Errorf("runBeforeRestore() on nil registry error = %v", err)
+	}
+	if err := r.runBeforeFind(ctx, "filter"); err != nil {
+		t.Errorf("runBeforeFind() on nil registry error = %v", err)
+	}
+}
@@ -0,0 +1,89 @@
+package mongosoftdelete
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// indexConfig holds index-related settings configured on a SoftDeleteMiddleware.
+// It lives behind a pointer so that scoped copies (see WithTrashed and
+// OnlyTrashed) keep sharing the same configuration.
+type indexConfig struct {
+	hardDeleteAfter time.Duration
+}
+
+// SetHardDeleteAfter configures EnsureIndexes to create a TTL index on
+// deletedAt, so that documents are automatically purged by MongoDB once
+// they have been soft deleted for longer than after.
+func (m *SoftDeleteMiddleware) SetHardDeleteAfter(after time.Duration) {
+	if m.indexConfig == nil {
+		m.indexConfig = &indexConfig{}
+	}
+	m.indexConfig.hardDeleteAfter = after
+}
+
+// EnsureIndexes creates the indexes this middleware relies on. A partial
+// index can only serve a query whose filter implies its
+// partialFilterExpression, so a single {deleted: 1} index can't help both
+// the active-document path (which filters deleted: {$exists: false}, see
+// trashScope.matchFilter) and the trashed path (deleted: true) at once.
+// EnsureIndexes therefore builds:
+//   - one compound index per compoundIndexes entry (a list of field names),
+//     partialFilterExpression'd to deleted: {$exists: false} — the same
+//     filter scopeActive's matchFilter produces, so the query planner
+//     recognizes every default-scope query's predicate as implying this
+//     index's partialFilterExpression and actually selects it, instead of
+//     falling back to a collection scan. MongoDB's partial index expressions
+//     only support a subset of query operators ($eq, $exists, $gt/$gte/$lt/
+//     $lte, $type, and top-level $and) — $ne is not among them, which is why
+//     matchFilter uses $exists rather than $ne too;
+//   - a small {deleted: 1} partial index filtered to deleted: true, so
+//     OnlyTrashed() queries stay cheap even though they're the minority case;
+//   - if SetHardDeleteAfter was called, a TTL index on deletedAt.
+func (m *SoftDeleteMiddleware) EnsureIndexes(ctx context.Context, compoundIndexes ...[]string) error {
+	var hardDeleteAfter time.Duration
+	if m.indexConfig != nil {
+		hardDeleteAfter = m.indexConfig.hardDeleteAfter
+	}
+
+	_, err := m.Collection.Indexes().CreateMany(ctx, buildIndexModels(hardDeleteAfter, compoundIndexes...))
+	return err
+}
+
+// buildIndexModels builds the index models EnsureIndexes creates, factored
+// out so the model shapes (keys, partialFilterExpression, TTL) can be
+// asserted on directly without a live mongo connection.
+func buildIndexModels(hardDeleteAfter time.Duration, compoundIndexes ...[]string) []mongo.IndexModel {
+	activeFilter := scopeActive.matchFilter()
+
+	models := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "deleted", Value: 1}},
+			Options: options.Index().SetPartialFilterExpression(bson.M{"deleted": true}),
+		},
+	}
+
+	for _, fields := range compoundIndexes {
+		keys := bson.D{}
+		for _, field := range fields {
+			keys = append(keys, bson.E{Key: field, Value: 1})
+		}
+		models = append(models, mongo.IndexModel{
+			Keys:    keys,
+			Options: options.Index().SetPartialFilterExpression(activeFilter),
+		})
+	}
+
+	if hardDeleteAfter > 0 {
+		models = append(models, mongo.IndexModel{
+			Keys:    bson.D{{Key: "deletedAt", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(hardDeleteAfter.Seconds())),
+		})
+	}
+
+	return models
+}
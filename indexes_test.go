@@ -0,0 +1,82 @@
+package mongosoftdelete
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestBuildIndexModelsBase(t *testing.T) {
+	models := buildIndexModels(0)
+
+	if len(models) != 1 {
+		t.Fatalf("got %d models, want 1 (just the OnlyTrashed index)", len(models))
+	}
+
+	want := bson.D{{Key: "deleted", Value: 1}}
+	if got := models[0].Keys; !reflect.DeepEqual(got, want) {
+		t.Errorf("Keys = %v, want %v", got, want)
+	}
+	if got := models[0].Options.PartialFilterExpression; !reflect.DeepEqual(got, bson.M{"deleted": true}) {
+		t.Errorf("PartialFilterExpression = %v, want %v", got, bson.M{"deleted": true})
+	}
+}
+
+func TestBuildIndexModelsCompound(t *testing.T) {
+	models := buildIndexModels(0, []string{"tenantID", "status"})
+
+	if len(models) != 2 {
+		t.Fatalf("got %d models, want 2 (base + 1 compound)", len(models))
+	}
+
+	compound := models[1]
+	wantKeys := bson.D{{Key: "tenantID", Value: 1}, {Key: "status", Value: 1}}
+	if got := compound.Keys; !reflect.DeepEqual(got, wantKeys) {
+		t.Errorf("Keys = %v, want %v", got, wantKeys)
+	}
+
+	wantFilter := scopeActive.matchFilter()
+	if got := compound.Options.PartialFilterExpression; !reflect.DeepEqual(got, wantFilter) {
+		t.Errorf("PartialFilterExpression = %v, want %v (must match trashScope.matchFilter's default scope so the planner can select this index)", got, wantFilter)
+	}
+}
+
+func TestBuildIndexModelsMultipleCompoundIndexes(t *testing.T) {
+	models := buildIndexModels(0, []string{"a"}, []string{"b", "c"})
+
+	if len(models) != 3 {
+		t.Fatalf("got %d models, want 3 (base + 2 compound)", len(models))
+	}
+}
+
+func TestBuildIndexModelsTTL(t *testing.T) {
+	models := buildIndexModels(48 * time.Hour)
+
+	if len(models) != 2 {
+		t.Fatalf("got %d models, want 2 (base + TTL)", len(models))
+	}
+
+	ttl := models[1]
+	wantKeys := bson.D{{Key: "deletedAt", Value: 1}}
+	if got := ttl.Keys; !reflect.DeepEqual(got, wantKeys) {
+		t.Errorf("Keys = %v, want %v", got, wantKeys)
+	}
+	if ttl.Options.ExpireAfterSeconds == nil {
+		t.Fatal("ExpireAfterSeconds is nil, want it set")
+	}
+	if got, want := *ttl.Options.ExpireAfterSeconds, int32((48 * time.Hour).Seconds()); got != want {
+		t.Errorf("ExpireAfterSeconds = %d, want %d", got, want)
+	}
+}
+
+func TestBuildIndexModelsNoTTLWhenZero(t *testing.T) {
+	models := buildIndexModels(0, []string{"a"})
+
+	for _, model := range models {
+		if reflect.DeepEqual(model.Keys, bson.D{{Key: "deletedAt", Value: 1}}) {
+			t.Error("a TTL index was built even though hardDeleteAfter was 0")
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package mongosoftdelete
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SoftDeleteFields is an embeddable struct giving a document its _id field
+// plus the soft-delete bookkeeping fields from SoftDeleteModel, along with
+// the GetID/SetID pair Repository relies on to identify documents.
+type SoftDeleteFields struct {
+	ID              primitive.ObjectID `bson:"_id,omitempty"`
+	SoftDeleteModel `bson:",inline"`
+}
+
+// GetID returns the document's identifier.
+func (f *SoftDeleteFields) GetID() primitive.ObjectID {
+	return f.ID
+}
+
+// SetID sets the document's identifier.
+func (f *SoftDeleteFields) SetID(id primitive.ObjectID) {
+	f.ID = id
+}
+
+// Repository is a typed wrapper around SoftDeleteMiddleware that decodes
+// query results directly into T, so callers stop dealing with *mongo.Cursor
+// and manual Decode calls.
+type Repository[T any] struct {
+	*SoftDeleteMiddleware
+}
+
+// NewRepository creates a Repository[T] backed by coll.
+func NewRepository[T any](coll *mongo.Collection) *Repository[T] {
+	return &Repository[T]{SoftDeleteMiddleware: New(coll)}
+}
+
+// WithTrashed returns a copy of the repository whose queries are not
+// filtered by soft-delete state, so both active and deleted documents are
+// visible. It overrides the promoted SoftDeleteMiddleware.WithTrashed so
+// that scoped calls keep decoding into T instead of degrading callers back
+// to *mongo.Cursor.
+func (r *Repository[T]) WithTrashed() *Repository[T] {
+	return &Repository[T]{SoftDeleteMiddleware: r.SoftDeleteMiddleware.WithTrashed()}
+}
+
+// OnlyTrashed returns a copy of the repository whose queries are restricted
+// to documents that have been soft deleted. It overrides the promoted
+// SoftDeleteMiddleware.OnlyTrashed for the same reason as WithTrashed.
+func (r *Repository[T]) OnlyTrashed() *Repository[T] {
+	return &Repository[T]{SoftDeleteMiddleware: r.SoftDeleteMiddleware.OnlyTrashed()}
+}
+
+// Find runs a soft-delete-aware Find and decodes every matching document into a []T.
+func (r *Repository[T]) Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) ([]T, error) {
+	cursor, err := r.SoftDeleteMiddleware.Find(ctx, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	results := make([]T, 0)
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// FindOne runs a soft-delete-aware FindOne and decodes the result into a T.
+func (r *Repository[T]) FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) (T, error) {
+	var result T
+	err := r.SoftDeleteMiddleware.FindOne(ctx, filter, opts...).Decode(&result)
+	return result, err
+}
+
+// Aggregate runs a soft-delete-aware Aggregate and decodes every resulting document into a []T.
+func (r *Repository[T]) Aggregate(ctx context.Context, pipeline interface{}, opts ...*options.AggregateOptions) ([]T, error) {
+	cursor, err := r.SoftDeleteMiddleware.Aggregate(ctx, pipeline, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	results := make([]T, 0)
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// FindOneAndUpdate runs a soft-delete-aware FindOneAndUpdate and decodes the result into a T.
+func (r *Repository[T]) FindOneAndUpdate(ctx context.Context, filter interface{}, update interface{}, opts ...*options.FindOneAndUpdateOptions) (T, error) {
+	var result T
+	err := r.SoftDeleteMiddleware.FindOneAndUpdate(ctx, filter, update, opts...).Decode(&result)
+	return result, err
+}
+
+// SoftDeleteByID soft deletes the document identified by id and decodes the
+// pre-update document into a T, using FindOneAndUpdate under the hood. Unlike
+// the untyped SoftDeleteMiddleware.SoftDeleteByID, it fetches the document
+// before deleting it and offers that typed document (not just the {"_id":
+// id} filter) to the BeforeSoftDelete/AfterSoftDelete hookable methods, so a
+// document's own hooks fire through this by-ID entry point.
+func (r *Repository[T]) SoftDeleteByID(ctx context.Context, id primitive.ObjectID, deletedBy primitive.ObjectID) (T, error) {
+	var result T
+	filter := bson.M{"_id": id}
+
+	if err := r.Collection.FindOne(ctx, filter).Decode(&result); err != nil {
+		return result, err
+	}
+	if err := r.hooks.runBeforeSoftDelete(ctx, filter, &result); err != nil {
+		return result, err
+	}
+	update := r.createSoftDeleteUpdate(deletedBy)
+	if err := r.Collection.FindOneAndUpdate(ctx, filter, update).Decode(&result); err != nil {
+		return result, err
+	}
+	return result, r.hooks.runAfterSoftDelete(ctx, filter, &result)
+}
+
+// RestoreByID restores the document identified by id and decodes the
+// post-restore document into a T, using FindOneAndUpdate under the hood.
+// Unlike the untyped SoftDeleteMiddleware.RestoreByID, it fetches the
+// document before restoring it and offers that typed document (not just the
+// {"_id": id} filter) to the BeforeRestore/AfterRestore hookable methods, so
+// a document's own hooks fire through this by-ID entry point.
+func (r *Repository[T]) RestoreByID(ctx context.Context, id primitive.ObjectID, restoredBy primitive.ObjectID) (T, error) {
+	var result T
+	filter := bson.M{"_id": id}
+
+	if err := r.Collection.FindOne(ctx, filter).Decode(&result); err != nil {
+		return result, err
+	}
+	if err := r.hooks.runBeforeRestore(ctx, filter, &result); err != nil {
+		return result, err
+	}
+	update := r.createRestoreUpdate(restoredBy)
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	if err := r.Collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&result); err != nil {
+		return result, err
+	}
+	return result, r.hooks.runAfterRestore(ctx, filter, &result)
+}
@@ -0,0 +1,44 @@
+package mongosoftdelete
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type testDoc struct {
+	SoftDeleteFields `bson:",inline"`
+	Name             string `bson:"name"`
+}
+
+func TestRepositoryWithTrashedOnlyTrashedScope(t *testing.T) {
+	repo := NewRepository[testDoc](nil)
+
+	if got := repo.scope; got != scopeActive {
+		t.Fatalf("NewRepository(...).scope = %v, want %v", got, scopeActive)
+	}
+
+	withTrashed := repo.WithTrashed()
+	if got := withTrashed.scope; got != scopeWithTrashed {
+		t.Errorf("WithTrashed().scope = %v, want %v", got, scopeWithTrashed)
+	}
+
+	onlyTrashed := repo.OnlyTrashed()
+	if got := onlyTrashed.scope; got != scopeOnlyTrashed {
+		t.Errorf("OnlyTrashed().scope = %v, want %v", got, scopeOnlyTrashed)
+	}
+
+	if got := repo.scope; got != scopeActive {
+		t.Errorf("repo.scope = %v, want %v (WithTrashed/OnlyTrashed must not mutate the receiver)", got, scopeActive)
+	}
+}
+
+func TestSoftDeleteFieldsGetSetID(t *testing.T) {
+	var f SoftDeleteFields
+	id := primitive.NewObjectID()
+
+	f.SetID(id)
+	if got := f.GetID(); got != id {
+		t.Errorf("GetID() = %v, want %v", got, id)
+	}
+}
@@ -0,0 +1,101 @@
+package mongosoftdelete
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// DeletionHistoryEntry records a single soft-delete or restore transition so that
+// repeated delete/restore cycles on the same document are not lost.
+type DeletionHistoryEntry struct {
+	Action string             `bson:"action"`
+	At     primitive.DateTime `bson:"at"`
+	By     primitive.ObjectID `bson:"by,omitempty"`
+}
+
+// RestoreOne reverses a soft delete on a single document, unsetting the deleted/
+// deletedAt/deletedBy fields and recording who performed the restore. filter
+// is an arbitrary query document, not a document instance, so
+// BeforeRestore/AfterRestore hookable methods only fire when filter itself
+// happens to implement them.
+func (m *SoftDeleteMiddleware) RestoreOne(ctx context.Context, filter interface{}, restoredBy primitive.ObjectID) (*mongo.UpdateResult, error) {
+	if err := m.hooks.runBeforeRestore(ctx, filter, nil); err != nil {
+		return nil, err
+	}
+	update := m.createRestoreUpdate(restoredBy)
+	result, err := m.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return result, err
+	}
+	return result, m.hooks.runAfterRestore(ctx, filter, nil)
+}
+
+// RestoreMany reverses a soft delete on every document matching filter. As
+// with RestoreOne, hookable methods only fire if filter implements them.
+func (m *SoftDeleteMiddleware) RestoreMany(ctx context.Context, filter interface{}, restoredBy primitive.ObjectID) (*mongo.UpdateResult, error) {
+	if err := m.hooks.runBeforeRestore(ctx, filter, nil); err != nil {
+		return nil, err
+	}
+	update := m.createRestoreUpdate(restoredBy)
+	result, err := m.Collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return result, err
+	}
+	return result, m.hooks.runAfterRestore(ctx, filter, nil)
+}
+
+// RestoreByID reverses a soft delete on the document identified by id.
+// filter is always the {"_id": id} query document built here, never the
+// document itself, so BeforeRestore/AfterRestore hookable methods never fire
+// through this entry point; use Repository[T].RestoreByID, which fetches the
+// typed document and offers it to the hook, when that matters.
+func (m *SoftDeleteMiddleware) RestoreByID(ctx context.Context, id primitive.ObjectID, restoredBy primitive.ObjectID) (*mongo.UpdateResult, error) {
+	filter := bson.M{"_id": id}
+	if err := m.hooks.runBeforeRestore(ctx, filter, nil); err != nil {
+		return nil, err
+	}
+	update := m.createRestoreUpdate(restoredBy)
+	result, err := m.Collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return result, err
+	}
+	return result, m.hooks.runAfterRestore(ctx, filter, nil)
+}
+
+// createRestoreUpdate creates the update payload for a restore operation. It unsets
+// the soft-delete fields, stamps restoredAt/restoredBy, and appends an entry to the
+// deletionHistory array so the delete/restore cycle is preserved for audit purposes.
+func (m *SoftDeleteMiddleware) createRestoreUpdate(restoredBy primitive.ObjectID) bson.M {
+	now := time.Now()
+
+	restorationPayload := bson.M{
+		"restoredAt": primitive.NewDateTimeFromTime(now),
+	}
+
+	// handling when restoredBy is provided
+	if !restoredBy.IsZero() {
+		restorationPayload["restoredBy"] = restoredBy
+	}
+
+	historyEntry := DeletionHistoryEntry{
+		Action: "restored",
+		At:     primitive.NewDateTimeFromTime(now),
+		By:     restoredBy,
+	}
+
+	return bson.M{
+		"$unset": bson.M{
+			"deleted":   "",
+			"deletedAt": "",
+			"deletedBy": "",
+		},
+		"$set": restorationPayload,
+		"$push": bson.M{
+			"deletionHistory": historyEntry,
+		},
+	}
+}
@@ -0,0 +1,82 @@
+package mongosoftdelete
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCreateRestoreUpdateShape(t *testing.T) {
+	m := &SoftDeleteMiddleware{}
+
+	t.Run("zero restoredBy omits restoredBy and By", func(t *testing.T) {
+		update := m.createRestoreUpdate(primitive.NilObjectID)
+
+		unset, ok := update["$unset"].(bson.M)
+		if !ok {
+			t.Fatalf("$unset is %T, want bson.M", update["$unset"])
+		}
+		wantUnset := bson.M{"deleted": "", "deletedAt": "", "deletedBy": ""}
+		if !reflect.DeepEqual(unset, wantUnset) {
+			t.Errorf("$unset = %v, want %v", unset, wantUnset)
+		}
+
+		set, ok := update["$set"].(bson.M)
+		if !ok {
+			t.Fatalf("$set is %T, want bson.M", update["$set"])
+		}
+		if _, ok := set["restoredBy"]; ok {
+			t.Error("$set contains restoredBy even though restoredBy was the zero ObjectID")
+		}
+		if _, ok := set["restoredAt"]; !ok {
+			t.Error("$set is missing restoredAt")
+		}
+
+		push, ok := update["$push"].(bson.M)
+		if !ok {
+			t.Fatalf("$push is %T, want bson.M", update["$push"])
+		}
+		entry, ok := push["deletionHistory"].(DeletionHistoryEntry)
+		if !ok {
+			t.Fatalf("$push.deletionHistory is %T, want DeletionHistoryEntry", push["deletionHistory"])
+		}
+		if entry.Action != "restored" {
+			t.Errorf("deletionHistory.Action = %q, want %q", entry.Action, "restored")
+		}
+		if !entry.By.IsZero() {
+			t.Errorf("deletionHistory.By = %v, want zero ObjectID", entry.By)
+		}
+	})
+
+	t.Run("non-zero restoredBy is set on both restorationPayload and history entry", func(t *testing.T) {
+		restoredBy := primitive.NewObjectID()
+		update := m.createRestoreUpdate(restoredBy)
+
+		set := update["$set"].(bson.M)
+		if got := set["restoredBy"]; got != restoredBy {
+			t.Errorf("$set.restoredBy = %v, want %v", got, restoredBy)
+		}
+
+		push := update["$push"].(bson.M)
+		entry := push["deletionHistory"].(DeletionHistoryEntry)
+		if entry.By != restoredBy {
+			t.Errorf("deletionHistory.By = %v, want %v", entry.By, restoredBy)
+		}
+	})
+
+	t.Run("restoredAt and deletionHistory.At share the same timestamp", func(t *testing.T) {
+		update := m.createRestoreUpdate(primitive.NilObjectID)
+
+		set := update["$set"].(bson.M)
+		restoredAt := set["restoredAt"].(primitive.DateTime)
+
+		push := update["$push"].(bson.M)
+		entry := push["deletionHistory"].(DeletionHistoryEntry)
+
+		if restoredAt != entry.At {
+			t.Errorf("restoredAt (%v) != deletionHistory.At (%v); createRestoreUpdate should stamp both from a single time.Now() call", restoredAt, entry.At)
+		}
+	})
+}
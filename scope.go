@@ -0,0 +1,79 @@
+package mongosoftdelete
+
+import "go.mongodb.org/mongo-driver/bson"
+
+// trashScope controls which documents a SoftDeleteMiddleware's queries are
+// allowed to see. The zero value (scopeActive) is the default, existing
+// behaviour: only non-deleted documents.
+type trashScope int
+
+const (
+	// scopeActive returns only documents that have not been soft deleted. This is the default scope.
+	scopeActive trashScope = iota
+	// scopeWithTrashed returns documents regardless of their soft-delete state.
+	scopeWithTrashed
+	// scopeOnlyTrashed returns only documents that have been soft deleted.
+	scopeOnlyTrashed
+)
+
+// matchFilter returns the bson filter fragment for the scope, to be merged
+// into a query filter or used as an aggregation $match stage. It returns nil
+// for scopeWithTrashed, since no filter needs to be applied in that case.
+//
+// The default-scope filter uses deleted: {$exists: false} rather than
+// deleted: {$ne: true}: restore always $unsets deleted (see
+// createRestoreUpdate) and SoftDeleteModel.Deleted is bson:",omitempty" so a
+// false value is never stored either, making the two filters equivalent in
+// practice — but only $exists: false matches EnsureIndexes's
+// partialFilterExpression closely enough for the query planner to recognize
+// the predicate implies it and actually pick the partial index; $ne is
+// invisible to that analysis, so the default-scope queries this filter
+// backs would otherwise never use the compound indexes EnsureIndexes builds.
+func (s trashScope) matchFilter() bson.M {
+	switch s {
+	case scopeOnlyTrashed:
+		return bson.M{"deleted": true}
+	case scopeWithTrashed:
+		return nil
+	default:
+		return bson.M{"deleted": bson.M{"$exists": false}}
+	}
+}
+
+// watchMatchFilter returns the bson $match stage value for Watch's change
+// stream, to be prepended ahead of the caller's pipeline. It matches on
+// operationType/updateDescription rather than on a document's deleted field,
+// since a change event has no document to filter on directly. It returns nil
+// for scopeWithTrashed, the same as matchFilter, so Watch skips the
+// prepended stage and every event passes through.
+func (s trashScope) watchMatchFilter() bson.M {
+	switch s {
+	case scopeOnlyTrashed:
+		return bson.M{
+			"operationType": "update",
+			"updateDescription.updatedFields.deleted": true,
+		}
+	case scopeWithTrashed:
+		return nil
+	default:
+		return bson.M{
+			"$or": bson.A{
+				bson.M{"operationType": bson.M{"$ne": "update"}},
+				bson.M{"updateDescription.updatedFields.deleted": bson.M{"$ne": true}},
+			},
+		}
+	}
+}
+
+// WithTrashed returns a copy of the middleware whose queries (Find, FindOne,
+// Aggregate, UpdateOne, UpdateMany, ...) are not filtered by soft-delete
+// state, so both active and deleted documents are visible.
+func (m *SoftDeleteMiddleware) WithTrashed() *SoftDeleteMiddleware {
+	return &SoftDeleteMiddleware{Collection: m.Collection, scope: scopeWithTrashed, hooks: m.hooks, cascades: m.cascades, indexConfig: m.indexConfig}
+}
+
+// OnlyTrashed returns a copy of the middleware whose queries are restricted
+// to documents that have been soft deleted.
+func (m *SoftDeleteMiddleware) OnlyTrashed() *SoftDeleteMiddleware {
+	return &SoftDeleteMiddleware{Collection: m.Collection, scope: scopeOnlyTrashed, hooks: m.hooks, cascades: m.cascades, indexConfig: m.indexConfig}
+}
@@ -0,0 +1,87 @@
+package mongosoftdelete
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestTrashScopeMatchFilter(t *testing.T) {
+	tests := []struct {
+		name  string
+		scope trashScope
+		want  bson.M
+	}{
+		{"active", scopeActive, bson.M{"deleted": bson.M{"$exists": false}}},
+		{"withTrashed", scopeWithTrashed, nil},
+		{"onlyTrashed", scopeOnlyTrashed, bson.M{"deleted": true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.scope.matchFilter(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("matchFilter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddSoftDeleteFilter(t *testing.T) {
+	userFilter := bson.M{"name": "alice"}
+
+	t.Run("active scope merges with $and", func(t *testing.T) {
+		m := &SoftDeleteMiddleware{scope: scopeActive}
+		want := bson.M{
+			"$and": []interface{}{
+				userFilter,
+				bson.M{"deleted": bson.M{"$exists": false}},
+			},
+		}
+		if got := m.addSoftDeleteFilter(userFilter); !reflect.DeepEqual(got, want) {
+			t.Errorf("addSoftDeleteFilter() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("active scope with nil filter returns bare scope filter", func(t *testing.T) {
+		m := &SoftDeleteMiddleware{scope: scopeActive}
+		want := bson.M{"deleted": bson.M{"$exists": false}}
+		if got := m.addSoftDeleteFilter(nil); !reflect.DeepEqual(got, want) {
+			t.Errorf("addSoftDeleteFilter(nil) = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("withTrashed scope passes the filter through untouched", func(t *testing.T) {
+		m := &SoftDeleteMiddleware{scope: scopeWithTrashed}
+		if got := m.addSoftDeleteFilter(userFilter); !reflect.DeepEqual(got, userFilter) {
+			t.Errorf("addSoftDeleteFilter() = %v, want %v", got, userFilter)
+		}
+	})
+
+	t.Run("onlyTrashed scope merges with $and", func(t *testing.T) {
+		m := &SoftDeleteMiddleware{scope: scopeOnlyTrashed}
+		want := bson.M{
+			"$and": []interface{}{
+				userFilter,
+				bson.M{"deleted": true},
+			},
+		}
+		if got := m.addSoftDeleteFilter(userFilter); !reflect.DeepEqual(got, want) {
+			t.Errorf("addSoftDeleteFilter() = %v, want %v", got, want)
+		}
+	})
+}
+
+func TestWithTrashedOnlyTrashedScope(t *testing.T) {
+	m := New(nil)
+
+	if got := m.WithTrashed().scope; got != scopeWithTrashed {
+		t.Errorf("WithTrashed().scope = %v, want %v", got, scopeWithTrashed)
+	}
+	if got := m.OnlyTrashed().scope; got != scopeOnlyTrashed {
+		t.Errorf("OnlyTrashed().scope = %v, want %v", got, scopeOnlyTrashed)
+	}
+	if got := m.scope; got != scopeActive {
+		t.Errorf("New(...).scope = %v, want %v (WithTrashed/OnlyTrashed must not mutate the receiver)", got, scopeActive)
+	}
+}
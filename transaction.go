@@ -0,0 +1,98 @@
+package mongosoftdelete
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// cascadeRegistration pairs a child collection with the field that holds the
+// parent document's ID, so a parent soft delete can be cascaded to it.
+type cascadeRegistration struct {
+	child   *mongo.Collection
+	fkField string
+}
+
+// filter builds the query selecting this registration's active child
+// documents that reference the parent id being cascaded. It uses the same
+// deleted: {$exists: false} shape as trashScope.matchFilter's default scope,
+// so this query can use the same kind of partial index as every other
+// active-document query in the package (see EnsureIndexes).
+func (reg cascadeRegistration) filter(id primitive.ObjectID) bson.M {
+	return bson.M{
+		reg.fkField: id,
+		"deleted":   bson.M{"$exists": false},
+	}
+}
+
+// cascadeRegistry holds the child collections registered for cascading soft
+// deletes. It lives behind a pointer so that scoped copies (see WithTrashed
+// and OnlyTrashed) keep sharing the same registrations.
+type cascadeRegistry struct {
+	registrations []cascadeRegistration
+}
+
+// Register declares that childColl has a fkField referencing this
+// middleware's collection, so that CascadeSoftDeleteByID also soft deletes
+// matching documents in childColl.
+func (m *SoftDeleteMiddleware) Register(childColl *mongo.Collection, fkField string) {
+	if m.cascades == nil {
+		m.cascades = &cascadeRegistry{}
+	}
+	m.cascades.registrations = append(m.cascades.registrations, cascadeRegistration{child: childColl, fkField: fkField})
+}
+
+// WithSession starts a mongo session and runs fn with a SessionContext that
+// can be passed as the ctx argument to any SoftDeleteMiddleware method,
+// threading the session through the operations fn performs.
+func (m *SoftDeleteMiddleware) WithSession(ctx context.Context, fn func(sessCtx mongo.SessionContext) error) error {
+	session, err := m.Collection.Database().Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	return mongo.WithSession(ctx, session, func(sessCtx mongo.SessionContext) error {
+		return fn(sessCtx)
+	})
+}
+
+// Transaction runs fn inside a mongo multi-document transaction, committing
+// if fn returns nil and aborting otherwise.
+func (m *SoftDeleteMiddleware) Transaction(ctx context.Context, fn func(sessCtx mongo.SessionContext) error) error {
+	session, err := m.Collection.Database().Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sessCtx)
+	})
+	return err
+}
+
+// CascadeSoftDeleteByID soft deletes the document identified by id together
+// with every document in a registered child collection whose fkField points
+// at id, atomically within a single transaction.
+func (m *SoftDeleteMiddleware) CascadeSoftDeleteByID(ctx context.Context, id primitive.ObjectID, deletedBy primitive.ObjectID) error {
+	return m.Transaction(ctx, func(sessCtx mongo.SessionContext) error {
+		if _, err := m.SoftDeleteByID(sessCtx, id, deletedBy); err != nil {
+			return err
+		}
+
+		if m.cascades == nil {
+			return nil
+		}
+
+		update := m.createSoftDeleteUpdate(deletedBy)
+		for _, reg := range m.cascades.registrations {
+			if _, err := reg.child.UpdateMany(sessCtx, reg.filter(id), update); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
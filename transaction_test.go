@@ -0,0 +1,42 @@
+package mongosoftdelete
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestRegister(t *testing.T) {
+	m := &SoftDeleteMiddleware{}
+
+	m.Register(nil, "parentID")
+	m.Register(nil, "ownerID")
+
+	if m.cascades == nil {
+		t.Fatal("Register did not initialize cascades")
+	}
+	if got := len(m.cascades.registrations); got != 2 {
+		t.Fatalf("got %d registrations, want 2", got)
+	}
+	if got := m.cascades.registrations[0].fkField; got != "parentID" {
+		t.Errorf("registrations[0].fkField = %q, want %q", got, "parentID")
+	}
+	if got := m.cascades.registrations[1].fkField; got != "ownerID" {
+		t.Errorf("registrations[1].fkField = %q, want %q", got, "ownerID")
+	}
+}
+
+func TestCascadeRegistrationFilter(t *testing.T) {
+	id := primitive.NewObjectID()
+	reg := cascadeRegistration{fkField: "parentID"}
+
+	want := bson.M{
+		"parentID": id,
+		"deleted":  bson.M{"$exists": false},
+	}
+	if got := reg.filter(id); !reflect.DeepEqual(got, want) {
+		t.Errorf("filter(%v) = %v, want %v", id, got, want)
+	}
+}